@@ -1,7 +1,22 @@
 package zerocfg
 
+import (
+	"fmt"
+	"time"
+)
+
 const noSource = "default"
 
+// setEvent records a single write to a node's value: which source wrote
+// it, the raw string it wrote, and when. node.history keeps one of these
+// per successful write to the option, oldest first, so the full
+// provenance trail survives even after later sources override it.
+type setEvent struct {
+	Source    string
+	RawValue  string
+	Timestamp time.Time
+}
+
 // node represents a single configuration option, including its name, description, aliases, value, and metadata.
 type node struct {
 	Name        string
@@ -9,11 +24,45 @@ type node struct {
 	Aliases     []string
 	Value       Value
 	setSource   string
+	setPriority int
+	rawValue    string
+	history     []setEvent
+	tags        []string
+	validators  []func(any) error
+	wasSet      bool
 	isSecret    bool
 	isRequired  bool
 	caller      string
 }
 
+// set writes raw through n.Value and records that the node has been
+// explicitly set by some source, as opposed to still holding its
+// compile-time default.
+func (n *node) set(raw string) error {
+	if err := n.Value.Set(raw); err != nil {
+		return err
+	}
+	n.wasSet = true
+	return nil
+}
+
+// recordSet appends a setEvent to n's history. It is called every time a
+// Source successfully writes n's value, including overrides by later,
+// higher-priority sources.
+func (n *node) recordSet(source, rawValue string) {
+	n.history = append(n.history, setEvent{Source: source, RawValue: rawValue, Timestamp: time.Now()})
+}
+
+// hasTag reports whether n carries tag among its classification tags.
+func (n *node) hasTag(tag string) bool {
+	for _, t := range n.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *node) pathName() string {
 	if n.caller == "" {
 		return n.Name
@@ -22,6 +71,18 @@ func (n *node) pathName() string {
 	return n.caller + ":" + n.Name
 }
 
+// displayValue returns the option's effective current value as a string,
+// whether or not a Source has ever written to it: it prefers the typed
+// value reported via Getter, and only falls back to the last raw string a
+// Source set (n.rawValue, "" if none) for Value implementations that
+// don't support Getter.
+func (n *node) displayValue() string {
+	if g, ok := n.Value.(Getter); ok {
+		return fmt.Sprint(g.Get())
+	}
+	return n.rawValue
+}
+
 func (n *node) source() string {
 	if n.setSource == "" {
 		return noSource
@@ -38,11 +99,33 @@ func (n *node) source() string {
 //     The string is produced by zerocfg's ToString conversion.
 //   - Must report its type name for identification and documentation:
 //     Type() string
+//
+// Whether an option has ever been set by a source is tracked on node
+// (wasSet, history), not on Value, so adding a feature that needs it
+// doesn't widen the contract every existing Value implementation must
+// satisfy.
 type Value interface {
 	Set(string) error
 	Type() string
 }
 
+// Getter is an optional extension of Value for types that can hand back
+// their current typed value, e.g. so Show can render an option's
+// compile-time default even though no Source has written to it, and so
+// Validate can run against the typed value instead of the Value wrapper.
+type Getter interface {
+	Get() any
+}
+
+// Cloner is an optional extension of Value for types whose Set depends on
+// unexported state captured at construction time beyond their data field
+// (e.g. a parser closure), so a zero-value copy of the concrete type
+// cannot Set safely. Validate's probe step uses Clone, when implemented,
+// instead of reflect-zeroing the live Value.
+type Cloner interface {
+	Clone() Value
+}
+
 // OptNode is a function that modifies a node during option registration.
 // It is used to apply additional behaviors such as aliases, secret marking, grouping, or required flags.
 //
@@ -100,3 +183,16 @@ func Required() OptNode {
 		n.isRequired = true
 	}
 }
+
+// Tag returns an OptNode that classifies a configuration option under one
+// or more free-form categories, e.g. "tuning", "output", "secret-source".
+// Tags drive selective rendering via ShowByTag and Nodes.
+//
+// Example:
+//
+//	timeout := Duration("http.timeout", time.Second, "request timeout", Tag("tuning"))
+func Tag(tags ...string) OptNode {
+	return func(n *node) {
+		n.tags = append(n.tags, tags...)
+	}
+}
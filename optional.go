@@ -0,0 +1,93 @@
+package zerocfg
+
+import (
+	"strconv"
+	"time"
+)
+
+// optionalValue adapts a parse function into a Value that tracks whether
+// it has ever been set, so Optional.Get can distinguish an explicit zero
+// from "no source ever configured this option".
+type optionalValue[T any] struct {
+	value    T
+	wasSet   bool
+	typeName string
+	parse    func(string) (T, error)
+}
+
+func (o *optionalValue[T]) Set(s string) error {
+	v, err := o.parse(s)
+	if err != nil {
+		return err
+	}
+	o.value = v
+	o.wasSet = true
+	return nil
+}
+
+func (o *optionalValue[T]) Type() string { return o.typeName }
+func (o *optionalValue[T]) Get() any     { return o.value }
+
+// Clone returns a fresh, unset optionalValue carrying the same parse
+// function, so Validate's probe step can Set it without the nil-parse
+// panic a zero-value reflect.New copy would hit.
+func (o *optionalValue[T]) Clone() Value {
+	return &optionalValue[T]{parse: o.parse, typeName: o.typeName}
+}
+
+// Optional wraps a configuration option whose caller needs to tell "never
+// set by any source" apart from an explicit zero value, e.g. applying a
+// timeout only when the operator configured one.
+type Optional[T any] struct {
+	v *optionalValue[T]
+}
+
+// Get returns the option's current value, or nil if no source has ever
+// set it.
+func (o *Optional[T]) Get() *T {
+	if !o.v.wasSet {
+		return nil
+	}
+
+	value := o.v.value
+	return &value
+}
+
+func newOptional[T any](path, desc, typeName string, parse func(string) (T, error), opts ...OptNode) *Optional[T] {
+	v := &optionalValue[T]{parse: parse, typeName: typeName}
+	n := &node{Name: path, Description: desc, Value: v}
+	for _, opt := range opts {
+		opt(n)
+	}
+	register(n)
+
+	return &Optional[T]{v: v}
+}
+
+// OptStr registers path as an optional string option, nil unless a source
+// explicitly set it.
+//
+// Example:
+//
+//	proxy := OptStr("http.proxy", "outbound proxy URL")
+//	// after Parse:
+//	if p := proxy.Get(); p != nil {
+//		client.SetProxy(*p)
+//	}
+func OptStr(path, desc string, opts ...OptNode) *Optional[string] {
+	return newOptional(path, desc, "string", func(s string) (string, error) { return s, nil }, opts...)
+}
+
+// OptInt registers path as an optional int option, nil unless a source
+// explicitly set it.
+func OptInt(path, desc string, opts ...OptNode) *Optional[int] {
+	return newOptional(path, desc, "int", strconv.Atoi, opts...)
+}
+
+// OptDuration registers path as an optional time.Duration option, nil
+// unless a source explicitly set it. This lets callers apply a timeout
+// only when it was explicitly configured, instead of confusing an unset
+// option with an explicit zero duration.
+func OptDuration(path, desc string, opts ...OptNode) *Optional[time.Duration] {
+	return newOptional(path, desc, "duration", time.ParseDuration, opts...)
+}
@@ -0,0 +1,38 @@
+package zerocfg
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidateWithOptionalDoesNotPanic reproduces a probeSet built from a
+// zero-value reflect.New copy of optionalValue: its parse closure, only
+// set inside newOptional, would be nil, so Set would panic on a nil-func
+// call instead of validating.
+func TestValidateWithOptionalDoesNotPanic(t *testing.T) {
+	opt := OptInt("optional_test.port", "port", Validate(func(v any) error {
+		if v.(int) <= 0 {
+			return errors.New("port must be positive")
+		}
+		return nil
+	}))
+	defer func() {
+		mu.Lock()
+		delete(registry, "optional_test.port")
+		mu.Unlock()
+	}()
+
+	if errs := applyValues("env", 1, map[string]string{"optional_test.port": "8080"}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if got := opt.Get(); got == nil || *got != 8080 {
+		t.Fatalf("expected 8080, got %v", got)
+	}
+
+	if errs := applyValues("env", 1, map[string]string{"optional_test.port": "-1"}); len(errs) != 1 {
+		t.Fatalf("expected one validation error, got %v", errs)
+	}
+	if got := opt.Get(); got == nil || *got != 8080 {
+		t.Fatalf("rejected update must leave the previous value in place, got %v", got)
+	}
+}
@@ -0,0 +1,33 @@
+package zerocfg
+
+// Origin returns the full provenance trail for the option at path, oldest
+// write first: every source that has ever set its value, the raw string
+// it wrote, and when. The last entry is the one currently in effect. An
+// unknown path or one no source has ever written returns nil.
+func Origin(path string) []setEvent {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	n, ok := registry[path]
+	if !ok || len(n.history) == 0 {
+		return nil
+	}
+
+	trail := make([]setEvent, len(n.history))
+	copy(trail, n.history)
+	return trail
+}
+
+// IsExplicitlySet reports whether the option at path was ever written by a
+// Source, as opposed to still holding its compile-time default. An
+// unknown path reports false.
+func IsExplicitlySet(path string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	n, ok := registry[path]
+	if !ok {
+		return false
+	}
+	return len(n.history) > 0
+}
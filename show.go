@@ -0,0 +1,119 @@
+package zerocfg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Nodes returns every registered option for which filter reports true, in
+// sorted-by-path order. A nil filter returns all registered options.
+//
+// The returned nodes alias live registry entries that applyValues can
+// mutate concurrently; callers that read more than pathName from them
+// (e.g. rendering a value) must hold mu.RLock for as long as they do, the
+// way ShowByTag does, instead of reading fields after Nodes returns.
+func Nodes(filter func(n *node) bool) []*node {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return nodesLocked(filter)
+}
+
+// nodesLocked is Nodes' implementation for callers that already hold mu,
+// so they can keep the lock held while they read more than pathName off
+// the result (see ShowByTag).
+func nodesLocked(filter func(n *node) bool) []*node {
+	out := make([]*node, 0, len(registry))
+	for _, n := range registry {
+		if filter == nil || filter(n) {
+			out = append(out, n)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].pathName() < out[j].pathName() })
+	return out
+}
+
+// showConfig holds the render options accepted by Show and ShowByTag.
+type showConfig struct {
+	withOrigins bool
+}
+
+// ShowOption modifies how Show and ShowByTag render an option.
+type ShowOption func(*showConfig)
+
+// WithOrigins returns a ShowOption that appends each option's full
+// provenance trail (see Origin) to its rendered line, so operators can see
+// not just the winning value but every layer that tried to set it.
+func WithOrigins() ShowOption {
+	return func(c *showConfig) {
+		c.withOrigins = true
+	}
+}
+
+// Show renders every registered option as a human-readable table of its
+// path, current value, type, and source. Secret options have their value
+// masked.
+func Show(opts ...ShowOption) string {
+	return ShowByTag(nil, nil, opts...)
+}
+
+// ShowByTag renders the subset of registered options whose tags satisfy
+// include and exclude: an option is shown when include is empty or it
+// carries at least one of the listed tags, and it carries none of the
+// tags in exclude. This is the mechanism behind per-subsystem --help
+// output and selective docs generation.
+func ShowByTag(include, exclude []string, opts ...ShowOption) string {
+	cfg := showConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Held for the whole render pass, not just while collecting nodes:
+	// displayValue/source/history below read fields applyValues mutates
+	// under mu.Lock, so releasing the lock after nodesLocked and before
+	// formatting would race with a concurrent Parse/WatchSource update.
+	mu.RLock()
+	defer mu.RUnlock()
+
+	nodes := nodesLocked(func(n *node) bool {
+		if len(include) > 0 && !anyTag(n, include) {
+			return false
+		}
+		if anyTag(n, exclude) {
+			return false
+		}
+		return true
+	})
+
+	var b strings.Builder
+	for _, n := range nodes {
+		value := n.displayValue()
+		if n.isSecret && value != "" {
+			value = "******"
+		}
+		fmt.Fprintf(&b, "%s=%s (%s, from %s)\n", n.pathName(), value, n.Value.Type(), n.source())
+
+		if cfg.withOrigins {
+			for _, ev := range n.history {
+				raw := ev.RawValue
+				if n.isSecret && raw != "" {
+					raw = "******"
+				}
+				fmt.Fprintf(&b, "    %s: %s @ %s\n", ev.Source, raw, ev.Timestamp.Format(time.RFC3339))
+			}
+		}
+	}
+	return b.String()
+}
+
+func anyTag(n *node, tags []string) bool {
+	for _, t := range tags {
+		if n.hasTag(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package zerocfg
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// TestShowConcurrentWithApplyValuesDoesNotRace exercises the pattern a
+// live WatchSource reload plus an operator calling Show() produces: one
+// goroutine mutating a node through applyValues while another renders it
+// through Show/ShowByTag. Run with -race; it asserts nothing beyond "no
+// panic", the point is to trip the race detector if the registry lock
+// ever stops covering the whole render pass again.
+func TestShowConcurrentWithApplyValuesDoesNotRace(t *testing.T) {
+	_, cleanup := withNode("race.counter", Tag("race"))
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			applyValues("race-source", 1, map[string]string{"race.counter": strconv.Itoa(i)})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = ShowByTag([]string{"race"}, nil, WithOrigins())
+		}
+	}()
+
+	wg.Wait()
+}
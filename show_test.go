@@ -0,0 +1,32 @@
+package zerocfg
+
+import (
+	"strings"
+	"testing"
+)
+
+// getterValue is a Value that also implements Getter, standing in for a
+// typed option constructor (Str, Int, ...) whose default is baked in at
+// construction time rather than written through Set.
+type getterValue struct {
+	value string
+}
+
+func (v *getterValue) Set(s string) error { v.value = s; return nil }
+func (v *getterValue) Type() string       { return "string" }
+func (v *getterValue) Get() any           { return v.value }
+
+func TestShowRendersDefaultForUnsetOption(t *testing.T) {
+	n := &node{Name: "app.name", Value: &getterValue{value: "myapp"}}
+	register(n)
+	defer func() {
+		mu.Lock()
+		delete(registry, "app.name")
+		mu.Unlock()
+	}()
+
+	out := Show()
+	if !strings.Contains(out, "app.name=myapp") {
+		t.Fatalf("expected Show to render the compile-time default, got: %q", out)
+	}
+}
@@ -0,0 +1,216 @@
+package zerocfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Source is implemented by anything that can supply raw string values for
+// registered options: files, environment variables, command-line flags,
+// remote key-value stores, and so on. Sources are consulted in priority
+// order by Parse, highest first, and the first source that provides a
+// value for a given option wins.
+type Source interface {
+	// Name identifies the source in error messages and Show output.
+	Name() string
+	// Priority determines merge order: sources with a higher Priority are
+	// consulted before sources with a lower one.
+	Priority() int
+	// Fetch returns the raw string values known to this source, keyed by
+	// the option's dotted path (see node.pathName).
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// WatchSource is a Source that can also stream subsequent updates, for
+// example a file watcher reacting to edits or a remote store pushing
+// change notifications. Parse starts one goroutine per WatchSource and
+// keeps applying its updates until ctx is canceled.
+type WatchSource interface {
+	Source
+
+	// Watch streams further snapshots of values for this source. The
+	// channel is closed once ctx is canceled or the source stops.
+	Watch(ctx context.Context) (<-chan map[string]string, error)
+}
+
+var (
+	mu        sync.RWMutex
+	registry  = map[string]*node{}
+	sources   []Source
+	observers = map[string][]func(old, new string){}
+)
+
+// register adds n to the package-wide registry so Parse and Show can find
+// it by path.
+func register(n *node) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	registry[n.pathName()] = n
+}
+
+// RegisterSource adds s to the pipeline consulted by Parse. It must be
+// called before Parse to take part in that call.
+func RegisterSource(s Source) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	sources = append(sources, s)
+}
+
+// OnChange registers fn to be called whenever the resolved value of the
+// option at path changes, whether from a later Parse call or from a
+// WatchSource update delivered after Parse returns.
+//
+// Example:
+//
+//	zerocfg.OnChange("db.host", func(old, new string) {
+//		log.Printf("db.host changed from %q to %q", old, new)
+//	})
+func OnChange(path string, fn func(old, new string)) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	observers[path] = append(observers[path], fn)
+}
+
+// Parse fetches values from every registered Source, highest Priority
+// first, and fills in every registered node that does not already carry a
+// value from another source of equal or higher priority. Sources implementing WatchSource
+// are then watched in the background for as long as ctx stays alive, with
+// later updates applied the same way and reported through OnChange.
+//
+// Parse returns a single error aggregating every per-source Fetch
+// failure; a failing source does not prevent the others from being
+// applied.
+func Parse(ctx context.Context) error {
+	mu.RLock()
+	ordered := make([]Source, len(sources))
+	copy(ordered, sources)
+	mu.RUnlock()
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority() > ordered[j].Priority()
+	})
+
+	var errs []error
+	for _, s := range ordered {
+		values, err := s.Fetch(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+			continue
+		}
+		errs = append(errs, applyValues(s.Name(), s.Priority(), values)...)
+
+		if ws, ok := s.(WatchSource); ok {
+			watch(ctx, ws)
+		}
+	}
+
+	errs = append(errs, checkRequired()...)
+
+	if err := runConstraints(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("zerocfg: parse: %w", errors.Join(errs...))
+}
+
+// watch runs s's Watch stream in the background, applying every update it
+// emits until the channel closes.
+func watch(ctx context.Context, s WatchSource) {
+	updates, err := s.Watch(ctx)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		for values := range updates {
+			applyValues(s.Name(), s.Priority(), values)
+		}
+	}()
+}
+
+// applyValues fills every registered node that is still unset, was last
+// set by sourceName itself, or was last set by a source of lower Priority
+// than priority with the matching raw value from values, running each node's validators and notifying its OnChange
+// observers when the value actually changes. This re-resolves priority on
+// every call, so a higher-priority WatchSource update delivered after
+// Parse can still take over a node a lower-priority source answered
+// first; it returns one error per node whose validators rejected the new
+// value.
+func applyValues(sourceName string, priority int, values map[string]string) []error {
+	mu.Lock()
+	type pending struct {
+		path     string
+		old, new string
+	}
+	var changed []pending
+	var errs []error
+
+	for path, n := range registry {
+		raw, ok := values[path]
+		if !ok {
+			continue
+		}
+		if n.wasSet && n.setSource != sourceName && n.setPriority >= priority {
+			continue // already filled by a source of equal or higher priority
+		}
+
+		prev := n.rawValue
+		if n.setSource == sourceName && prev == raw {
+			continue
+		}
+		if err := n.validate(raw); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := n.set(raw); err != nil {
+			continue
+		}
+		n.setSource = sourceName
+		n.setPriority = priority
+		n.rawValue = raw
+		n.recordSet(sourceName, raw)
+		changed = append(changed, pending{path: n.pathName(), old: prev, new: raw})
+	}
+	mu.Unlock()
+
+	for _, c := range changed {
+		fire(c.path, c.old, c.new)
+	}
+
+	return errs
+}
+
+// checkRequired returns one error per registered option marked Required
+// that no source has ever set, fulfilling Required's documented contract
+// that Parse rejects a configuration missing such an option.
+func checkRequired() []error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var errs []error
+	for path, n := range registry {
+		if n.isRequired && !n.wasSet {
+			errs = append(errs, fmt.Errorf("%s: required option not set", path))
+		}
+	}
+	return errs
+}
+
+func fire(path, old, new string) {
+	mu.RLock()
+	fns := observers[path]
+	mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(old, new)
+	}
+}
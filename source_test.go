@@ -0,0 +1,80 @@
+package zerocfg
+
+import "testing"
+
+// testValue is a minimal Value used to exercise the merge/validate
+// machinery without depending on any concrete typed option constructor.
+type testValue struct {
+	raw string
+	set bool
+}
+
+func (v *testValue) Set(s string) error { v.raw = s; v.set = true; return nil }
+func (v *testValue) Type() string       { return "test" }
+func (v *testValue) Get() any           { return v.raw }
+
+func withNode(path string, opts ...OptNode) (*node, func()) {
+	n := &node{Name: path, Value: &testValue{}}
+	for _, opt := range opts {
+		opt(n)
+	}
+	register(n)
+
+	return n, func() {
+		mu.Lock()
+		delete(registry, path)
+		mu.Unlock()
+	}
+}
+
+func TestApplyValuesReResolvesPriorityOnEveryUpdate(t *testing.T) {
+	n, cleanup := withNode("db.host")
+	defer cleanup()
+
+	if errs := applyValues("file", 1, map[string]string{"db.host": "file-value"}); len(errs) != 0 {
+		t.Fatalf("file write: unexpected errors: %v", errs)
+	}
+	if n.rawValue != "file-value" || n.setSource != "file" {
+		t.Fatalf("expected file-value/file, got %q/%q", n.rawValue, n.setSource)
+	}
+
+	// A higher-priority source arriving later (e.g. via WatchSource) must
+	// still be able to take over, not be permanently locked out by the
+	// low-priority source that answered first.
+	if errs := applyValues("env", 5, map[string]string{"db.host": "env-value"}); len(errs) != 0 {
+		t.Fatalf("env write: unexpected errors: %v", errs)
+	}
+	if n.rawValue != "env-value" || n.setSource != "env" {
+		t.Fatalf("expected env to take over, got %q/%q", n.rawValue, n.setSource)
+	}
+
+	// A subsequent lower-priority update must not be able to clobber it back.
+	if errs := applyValues("file", 1, map[string]string{"db.host": "file-value-2"}); len(errs) != 0 {
+		t.Fatalf("second file write: unexpected errors: %v", errs)
+	}
+	if n.rawValue != "env-value" || n.setSource != "env" {
+		t.Fatalf("lower-priority source must not override, got %q/%q", n.rawValue, n.setSource)
+	}
+}
+
+func TestCheckRequiredRejectsUnsetOption(t *testing.T) {
+	_, cleanup := withNode("db.user", Required())
+	defer cleanup()
+
+	errs := checkRequired()
+	if len(errs) != 1 {
+		t.Fatalf("expected one required-option error, got %v", errs)
+	}
+}
+
+func TestCheckRequiredAcceptsSetOption(t *testing.T) {
+	_, cleanup := withNode("db.user", Required())
+	defer cleanup()
+
+	if errs := applyValues("env", 1, map[string]string{"db.user": "alice"}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs := checkRequired(); len(errs) != 0 {
+		t.Fatalf("expected no required-option errors, got %v", errs)
+	}
+}
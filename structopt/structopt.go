@@ -0,0 +1,225 @@
+// Package structopt registers a whole configuration struct with zerocfg in
+// one call, deriving each option's path, default, description, and
+// modifiers from a `zcfg` struct tag instead of one constructor call per
+// field.
+package structopt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boro8eyy/zerocfg"
+)
+
+const tagKey = "zcfg"
+
+// Register walks cfg, a pointer to a struct, and registers every tagged
+// field as a zerocfg option, keeping the field in sync with the resolved
+// value for the life of the program via zerocfg.OnChange: reading cfg's
+// fields any time after Parse (or after a later WatchSource reload)
+// reflects what was actually resolved, not just the tag's default.
+// A nested struct field with a zcfg tag becomes a group keyed by the path
+// in that tag; an untagged nested struct is walked with the parent's
+// prefix unchanged.
+//
+// Field tags use the form:
+//
+//	zcfg:"db.port,default=5432,desc=database port,alias=p,required,secret"
+//
+// The first segment is the option's dotted path; the remaining
+// comma-separated segments are either bare flags (required, secret) or
+// key=value pairs (default, desc, alias). Non-struct fields without a zcfg
+// tag are skipped.
+//
+// Example:
+//
+//	type Config struct {
+//		Port int    `zcfg:"db.port,default=5432,desc=database port"`
+//		Host string `zcfg:"db.host,default=localhost,desc=database host"`
+//	}
+//	var cfg Config
+//	structopt.Register(&cfg)
+//	zerocfg.Parse(ctx) // cfg.Port, cfg.Host now hold the resolved values
+func Register(cfg any) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("structopt: Register expects a pointer to a struct, got %T", cfg)
+	}
+
+	return registerStruct(v.Elem(), "")
+}
+
+func registerStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			sub := prefix
+			if tag, ok := field.Tag.Lookup(tagKey); ok {
+				sub = joinPath(prefix, strings.Split(tag, ",")[0])
+			}
+			if err := registerStruct(fv, sub); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(tagKey)
+		if !ok {
+			continue
+		}
+
+		if err := registerField(fv, field, tag, prefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func joinPath(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case name == "":
+		return prefix
+	default:
+		return prefix + "." + name
+	}
+}
+
+// parsedTag holds the decoded parts of a zcfg struct tag.
+type parsedTag struct {
+	path     string
+	def      string
+	desc     string
+	alias    string
+	required bool
+	secret   bool
+}
+
+func parseTag(tag, prefix string) parsedTag {
+	parts := strings.Split(tag, ",")
+	p := parsedTag{path: joinPath(prefix, parts[0])}
+
+	for _, part := range parts[1:] {
+		switch {
+		case part == "required":
+			p.required = true
+		case part == "secret":
+			p.secret = true
+		case strings.HasPrefix(part, "default="):
+			p.def = strings.TrimPrefix(part, "default=")
+		case strings.HasPrefix(part, "desc="):
+			p.desc = strings.TrimPrefix(part, "desc=")
+		case strings.HasPrefix(part, "alias="):
+			p.alias = strings.TrimPrefix(part, "alias=")
+		}
+	}
+
+	return p
+}
+
+// registerField registers fv's tagged option with zerocfg and keeps fv in
+// sync with the resolved value for the rest of the program's life: the
+// zerocfg constructors below only fill in *their own* pointer when a
+// Source writes it, so without this the struct field would forever hold
+// its tag-declared default. zerocfg.OnChange fires on every later write,
+// including one delivered by a WatchSource after Parse has already
+// returned, so it re-applies the raw value into fv via reflection each
+// time instead of copying it once at registration.
+func registerField(fv reflect.Value, field reflect.StructField, tag, prefix string) error {
+	p := parseTag(tag, prefix)
+
+	opts := make([]zerocfg.OptNode, 0, 3)
+	if p.alias != "" {
+		opts = append(opts, zerocfg.Alias(p.alias))
+	}
+	if p.required {
+		opts = append(opts, zerocfg.Required())
+	}
+	if p.secret {
+		opts = append(opts, zerocfg.Secret())
+	}
+
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		def, err := time.ParseDuration(orDefault(p.def, "0s"))
+		if err != nil {
+			return fmt.Errorf("structopt: field %s: invalid duration default %q: %w", field.Name, p.def, err)
+		}
+		zerocfg.Duration(p.path, def, p.desc, opts...)
+		fv.SetInt(int64(def))
+		zerocfg.OnChange(p.path, func(_, raw string) {
+			if d, err := time.ParseDuration(raw); err == nil {
+				fv.SetInt(int64(d))
+			}
+		})
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		zerocfg.Str(p.path, p.def, p.desc, opts...)
+		fv.SetString(p.def)
+		zerocfg.OnChange(p.path, func(_, raw string) {
+			fv.SetString(raw)
+		})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		def, err := strconv.Atoi(orDefault(p.def, "0"))
+		if err != nil {
+			return fmt.Errorf("structopt: field %s: invalid int default %q: %w", field.Name, p.def, err)
+		}
+		zerocfg.Int(p.path, def, p.desc, opts...)
+		fv.SetInt(int64(def))
+		zerocfg.OnChange(p.path, func(_, raw string) {
+			if v, err := strconv.Atoi(raw); err == nil {
+				fv.SetInt(int64(v))
+			}
+		})
+	case reflect.Bool:
+		def := orDefault(p.def, "false") == "true"
+		zerocfg.Bool(p.path, def, p.desc, opts...)
+		fv.SetBool(def)
+		zerocfg.OnChange(p.path, func(_, raw string) {
+			fv.SetBool(raw == "true")
+		})
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("structopt: field %s: unsupported slice element type %s", field.Name, fv.Type().Elem())
+		}
+		var def []string
+		if p.def != "" {
+			def = strings.Split(p.def, "|")
+		}
+		zerocfg.StrSlice(p.path, def, p.desc, opts...)
+		fv.Set(reflect.ValueOf(def))
+		zerocfg.OnChange(p.path, func(_, raw string) {
+			var v []string
+			if raw != "" {
+				v = strings.Split(raw, "|")
+			}
+			fv.Set(reflect.ValueOf(v))
+		})
+	default:
+		return fmt.Errorf("structopt: field %s: unsupported type %s", field.Name, fv.Type())
+	}
+
+	return nil
+}
+
+func orDefault(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
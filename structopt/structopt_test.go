@@ -0,0 +1,53 @@
+package structopt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boro8eyy/zerocfg"
+)
+
+type fakeSource struct {
+	name   string
+	values map[string]string
+}
+
+func (s fakeSource) Name() string                                     { return s.name }
+func (s fakeSource) Priority() int                                    { return 10 }
+func (s fakeSource) Fetch(context.Context) (map[string]string, error) { return s.values, nil }
+
+type testConfig struct {
+	Port int    `zcfg:"structopt_test.port,default=5432,desc=database port"`
+	Host string `zcfg:"structopt_test.host,default=localhost,desc=database host"`
+}
+
+// TestRegisterFieldReflectsResolvedValueAfterParse guards against
+// Register copying a Source's value into the struct field once, at
+// Register time, instead of keeping the field bound to what Parse
+// actually resolves.
+func TestRegisterFieldReflectsResolvedValueAfterParse(t *testing.T) {
+	var cfg testConfig
+	if err := Register(&cfg); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if cfg.Port != 5432 || cfg.Host != "localhost" {
+		t.Fatalf("expected tag defaults before Parse, got %+v", cfg)
+	}
+
+	zerocfg.RegisterSource(fakeSource{name: "test", values: map[string]string{
+		"structopt_test.port": "6543",
+		"structopt_test.host": "db.internal",
+	}})
+
+	if err := zerocfg.Parse(context.Background()); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cfg.Port != 6543 {
+		t.Fatalf("expected cfg.Port to reflect the resolved value 6543, got %d", cfg.Port)
+	}
+	if cfg.Host != "db.internal" {
+		t.Fatalf("expected cfg.Host to reflect the resolved value db.internal, got %q", cfg.Host)
+	}
+}
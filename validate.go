@@ -0,0 +1,190 @@
+package zerocfg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Snapshot is a read-only view over every resolved option, passed to
+// constraint functions registered via AddConstraint. It lets a constraint
+// compare several options without reaching into the package's internal
+// registry. Int and Duration parse the resolved raw value instead of
+// comparing it lexicographically, since comparing numbers as strings
+// gives wrong answers (e.g. "9" > "10").
+type Snapshot interface {
+	// Get returns the raw string value currently resolved for path, and
+	// whether any source has ever set it.
+	Get(path string) (string, bool)
+	// Int returns path's resolved value parsed as an int, and whether it
+	// was both set and valid as an int.
+	Int(path string) (int, bool)
+	// Duration returns path's resolved value parsed as a time.Duration,
+	// and whether it was both set and valid as a duration.
+	Duration(path string) (time.Duration, bool)
+}
+
+type registrySnapshot struct {
+	values map[string]string
+}
+
+func (s registrySnapshot) Get(path string) (string, bool) {
+	v, ok := s.values[path]
+	return v, ok
+}
+
+func (s registrySnapshot) Int(path string) (int, bool) {
+	raw, ok := s.Get(path)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.Atoi(raw)
+	return v, err == nil
+}
+
+func (s registrySnapshot) Duration(path string) (time.Duration, bool) {
+	raw, ok := s.Get(path)
+	if !ok {
+		return 0, false
+	}
+	v, err := time.ParseDuration(raw)
+	return v, err == nil
+}
+
+func snapshot() Snapshot {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	values := make(map[string]string, len(registry))
+	for path, n := range registry {
+		if len(n.history) > 0 {
+			values[path] = n.rawValue
+		}
+	}
+	return registrySnapshot{values: values}
+}
+
+var constraints []func(Snapshot) error
+
+// AddConstraint registers a cross-field rule run once per Parse call,
+// after every source has been merged, against a read-only Snapshot of the
+// resolved configuration. Use this for rules that span more than one
+// option; single-option rules belong in Validate instead.
+//
+// Example:
+//
+//	zerocfg.AddConstraint(func(s zerocfg.Snapshot) error {
+//		min, _ := s.Int("pool.min")
+//		max, _ := s.Int("pool.max")
+//		if min > max {
+//			return errors.New("pool.min must not exceed pool.max")
+//		}
+//		return nil
+//	})
+func AddConstraint(fn func(Snapshot) error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	constraints = append(constraints, fn)
+}
+
+// Validate returns an OptNode that runs fn against the typed value parsed
+// from each raw string a Source offers for the option, before that value
+// is written. A non-nil error rejects the write, leaving the previous
+// value in place, and is collected and surfaced from the Parse call that
+// offered it.
+//
+// Example:
+//
+//	port := Int("db.port", 5432, "database port", Validate(func(v any) error {
+//		if p := v.(int); p <= 0 || p > 65535 {
+//			return fmt.Errorf("db.port: %d out of range", p)
+//		}
+//		return nil
+//	}))
+func Validate(fn func(any) error) OptNode {
+	return func(n *node) {
+		n.validators = append(n.validators, fn)
+	}
+}
+
+// validate runs n's validators against raw before it is ever written to
+// the live Value, so a rejected write never reaches the pointer callers
+// dereference. It parses raw into a scratch copy of n.Value's concrete
+// type and hands each validator the typed value reported through Getter
+// (falling back to the scratch Value itself when Getter isn't
+// implemented), matching what the validator's own doc example expects to
+// receive.
+func (n *node) validate(raw string) error {
+	if len(n.validators) == 0 {
+		return nil
+	}
+
+	v, err := n.probeSet(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", n.pathName(), err)
+	}
+
+	for _, fn := range n.validators {
+		if err := fn(v); err != nil {
+			return fmt.Errorf("%s: %w", n.pathName(), err)
+		}
+	}
+	return nil
+}
+
+// probeSet parses raw into a fresh instance of n.Value's concrete type,
+// without mutating the live value, and returns what a validator should
+// see: the typed value reported via Getter when supported, or the probe
+// Value itself otherwise.
+//
+// When n.Value implements Cloner, Clone provides the probe so unexported
+// construction-time state (e.g. a parser closure on Optional's backing
+// Value) survives into it. Reflect-zeroing the live Value's type, as the
+// fallback below does, only works for Value implementations whose Set
+// depends solely on their zero-valued data field.
+func (n *node) probeSet(raw string) (any, error) {
+	var probe Value
+	if c, ok := n.Value.(Cloner); ok {
+		probe = c.Clone()
+	} else {
+		rv := reflect.ValueOf(n.Value)
+		if rv.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("zerocfg: %T does not support Validate (not a pointer Value)", n.Value)
+		}
+		probe = reflect.New(rv.Elem().Type()).Interface().(Value)
+	}
+
+	if err := probe.Set(raw); err != nil {
+		return nil, err
+	}
+	if g, ok := probe.(Getter); ok {
+		return g.Get(), nil
+	}
+	return probe, nil
+}
+
+// runConstraints runs every registered cross-field constraint against the
+// current Snapshot, aggregating their errors into one.
+func runConstraints() error {
+	mu.RLock()
+	fns := make([]func(Snapshot) error, len(constraints))
+	copy(fns, constraints)
+	mu.RUnlock()
+
+	snap := snapshot()
+
+	var errs []error
+	for _, fn := range fns {
+		if err := fn(snap); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.Join(errs...)
+}
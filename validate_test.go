@@ -0,0 +1,67 @@
+package zerocfg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateRunsAgainstTypedValueNotTheValueWrapper(t *testing.T) {
+	n, cleanup := withNode("db.port", Validate(func(v any) error {
+		if _, ok := v.(string); !ok {
+			t.Fatalf("validator got %T, want string", v)
+		}
+		return nil
+	}))
+	defer cleanup()
+
+	if err := n.validate("5432"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRejectionLeavesLiveValueUntouched(t *testing.T) {
+	n, cleanup := withNode("db.port", Validate(func(v any) error {
+		if v.(string) == "-7" {
+			return errors.New("out of range")
+		}
+		return nil
+	}))
+	defer cleanup()
+
+	errs := applyValues("env", 1, map[string]string{"db.port": "-7"})
+	if len(errs) != 1 {
+		t.Fatalf("expected one validation error, got %v", errs)
+	}
+
+	tv := n.Value.(*testValue)
+	if tv.set || tv.raw != "" {
+		t.Fatalf("rejected value must not reach the live Value, got raw=%q set=%v", tv.raw, tv.set)
+	}
+	if n.wasSet || n.setSource != "" {
+		t.Fatalf("rejected value must not be recorded as set, got setSource=%q wasSet=%v", n.setSource, n.wasSet)
+	}
+}
+
+func TestSnapshotIntComparesNumerically(t *testing.T) {
+	_, cleanupMin := withNode("pool.min")
+	defer cleanupMin()
+	_, cleanupMax := withNode("pool.max")
+	defer cleanupMax()
+
+	if errs := applyValues("env", 1, map[string]string{"pool.min": "9", "pool.max": "10"}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	snap := snapshot()
+	min, ok := snap.Int("pool.min")
+	if !ok || min != 9 {
+		t.Fatalf("expected pool.min=9, got %d ok=%v", min, ok)
+	}
+	max, ok := snap.Int("pool.max")
+	if !ok || max != 10 {
+		t.Fatalf("expected pool.max=10, got %d ok=%v", max, ok)
+	}
+	if min > max {
+		t.Fatalf("numeric comparison should find 9 <= 10")
+	}
+}